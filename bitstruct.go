@@ -0,0 +1,196 @@
+package construct
+
+import (
+	"errors"
+	"io"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// BitStruct — sub-byte packed formats (IPv4 version:4/IHL:4, MP3 frame
+// headers, MODBUS, ...) where every other Field in this package is
+// byte-aligned. A BitStruct reads/writes individual bits through a small
+// bit-level reader/writer and must total a whole number of bytes; it parses
+// MSB-first unless LSBFirst is set.
+// ─────────────────────────────────────────────────────────────────────────────
+
+// bitwiseField is implemented by BitField, BitFlag, and BitPadding — the only
+// fields a BitStruct accepts, since bit-granular Parse/Build doesn't fit the
+// byte-oriented io.Reader/io.Writer Field interface.
+type bitwiseField interface {
+	width() int
+	parseBits(br *bitReader) (any, error)
+	buildBits(bw *bitWriter, v any) error
+}
+
+// BitField reads/writes Width bits (1-64) as a uint64, MSB-first within the
+// field unless the enclosing BitStruct sets LSBFirst.
+type BitField struct{ Width int }
+
+func (b BitField) width() int { return b.Width }
+func (b BitField) parseBits(br *bitReader) (any, error) { return br.readBits(b.Width) }
+func (b BitField) buildBits(bw *bitWriter, v any) error {
+	u, ok := toUint64(v)
+	if !ok {
+		return errors.New("BitField: expected an integer value")
+	}
+	return bw.writeBits(u, b.Width)
+}
+
+// BitFlag is a single bit surfaced as a bool.
+type BitFlag struct{}
+
+func (BitFlag) width() int { return 1 }
+func (BitFlag) parseBits(br *bitReader) (any, error) {
+	v, err := br.readBits(1)
+	return v != 0, err
+}
+func (BitFlag) buildBits(bw *bitWriter, v any) error {
+	b, ok := v.(bool)
+	if !ok {
+		return errors.New("BitFlag: expected a bool value")
+	}
+	var u uint64
+	if b {
+		u = 1
+	}
+	return bw.writeBits(u, 1)
+}
+
+// BitPadding skips Width bits, writing zeros on Build.
+type BitPadding struct{ Width int }
+
+func (p BitPadding) width() int { return p.Width }
+func (p BitPadding) parseBits(br *bitReader) (any, error) {
+	_, err := br.readBits(p.Width)
+	return nil, err
+}
+func (p BitPadding) buildBits(bw *bitWriter, _ any) error {
+	return bw.writeBits(0, p.Width)
+}
+
+// BitStruct is a Field over bitwiseField children, MSB-first by default.
+type BitStruct struct {
+	Fields   []bitwiseField
+	LSBFirst bool
+}
+
+func (s BitStruct) totalBits() int {
+	total := 0
+	for _, f := range s.Fields {
+		total += f.width()
+	}
+	return total
+}
+
+func (s BitStruct) Parse(r io.Reader) (any, error) {
+	if s.totalBits()%8 != 0 {
+		return nil, errors.New("BitStruct: fields must total a whole number of bytes")
+	}
+	br := &bitReader{r: r, lsbFirst: s.LSBFirst}
+	values := make([]any, len(s.Fields))
+	for i, f := range s.Fields {
+		v, err := f.parseBits(br)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (s BitStruct) Build(w io.Writer, v any) error {
+	if s.totalBits()%8 != 0 {
+		return errors.New("BitStruct: fields must total a whole number of bytes")
+	}
+	values, ok := v.([]any)
+	if !ok || len(values) != len(s.Fields) {
+		return errors.New("BitStruct: value must be []any of correct length")
+	}
+	bw := &bitWriter{w: w, lsbFirst: s.LSBFirst}
+	for i, f := range s.Fields {
+		if err := f.buildBits(bw, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// bitReader/bitWriter — bit-level cursor over an io.Reader/io.Writer backed
+// by a single pending byte.
+// ─────────────────────────────────────────────────────────────────────────────
+type bitReader struct {
+	r        io.Reader
+	buf      byte
+	nbits    uint // unread bits remaining in buf
+	lsbFirst bool
+}
+
+func (br *bitReader) readBits(n int) (uint64, error) {
+	var result uint64
+	pos := 0 // LSBFirst: how many result bits already placed
+	for n > 0 {
+		if br.nbits == 0 {
+			var b [1]byte
+			if _, err := io.ReadFull(br.r, b[:]); err != nil {
+				return 0, err
+			}
+			br.buf = b[0]
+			br.nbits = 8
+		}
+		take := n
+		if take > int(br.nbits) {
+			take = int(br.nbits)
+		}
+		mask := byte(1<<uint(take) - 1)
+		if br.lsbFirst {
+			bits := br.buf & mask
+			result |= uint64(bits) << uint(pos)
+			br.buf >>= uint(take)
+			pos += take
+		} else {
+			shift := br.nbits - uint(take)
+			bits := (br.buf >> shift) & mask
+			result = (result << uint(take)) | uint64(bits)
+		}
+		br.nbits -= uint(take)
+		n -= take
+	}
+	return result, nil
+}
+
+type bitWriter struct {
+	w        io.Writer
+	buf      byte
+	nbits    uint // bits already placed in buf
+	lsbFirst bool
+}
+
+func (bw *bitWriter) writeBits(v uint64, n int) error {
+	for n > 0 {
+		free := 8 - int(bw.nbits)
+		take := n
+		if take > free {
+			take = free
+		}
+		mask := byte(1<<uint(take) - 1)
+		if bw.lsbFirst {
+			bits := byte(v) & mask
+			bw.buf |= bits << bw.nbits
+			v >>= uint(take)
+		} else {
+			bits := byte(v>>uint(n-take)) & mask
+			bw.buf |= bits << uint(free-take)
+		}
+		bw.nbits += uint(take)
+		n -= take
+		if bw.nbits == 8 {
+			if _, err := bw.w.Write([]byte{bw.buf}); err != nil {
+				return err
+			}
+			bw.buf = 0
+			bw.nbits = 0
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package construct
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBitStruct_ParseBuild(t *testing.T) {
+	// IPv4 version:4 / IHL:4 packed into a single byte: 0x45 = version 4, IHL 5.
+	s := BitStruct{Fields: []bitwiseField{
+		BitField{Width: 4}, // version
+		BitField{Width: 4}, // IHL
+	}}
+
+	values, err := s.Parse(bytes.NewReader([]byte{0x45}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := values.([]any)
+	if got[0] != uint64(4) || got[1] != uint64(5) {
+		t.Errorf("unexpected values: %v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := s.Build(&buf, []any{uint64(4), uint64(5)}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0x45}) {
+		t.Errorf("unexpected output: %x", buf.Bytes())
+	}
+}
+
+func TestBitStruct_NonByteAligned(t *testing.T) {
+	s := BitStruct{Fields: []bitwiseField{BitField{Width: 3}}}
+	if _, err := s.Parse(bytes.NewReader([]byte{0x00})); err == nil {
+		t.Error("expected error for non-byte-aligned BitStruct")
+	}
+}
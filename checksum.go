@@ -0,0 +1,224 @@
+package construct
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/adler32"
+	"hash/crc32"
+	"io"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Checksum — wraps a Field with a checksum that's computed on Build and
+// verified on Parse, plus Computed for sibling values (e.g. chunk lengths)
+// that should be filled in automatically instead of passed by the caller.
+// Together these turn the PNG IHDR example at the end of construct.go from
+// a toy (CRC hardcoded) into a working round-trip.
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ChecksumAlgo computes a digest over raw bytes. Sum's length must match the
+// width of Checksum's StoredAs field (e.g. 4 for CRC32 with Uint32be, 16 for
+// MD5 with Bytes{Length: 16}); a mismatch is an error on Parse and Build.
+type ChecksumAlgo interface {
+	Sum(data []byte) []byte
+}
+
+type CRC32IEEE struct{}
+
+func (CRC32IEEE) Sum(data []byte) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], crc32.ChecksumIEEE(data))
+	return b[:]
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+type CRC32Castagnoli struct{}
+
+func (CRC32Castagnoli) Sum(data []byte) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], crc32.Checksum(data, crc32cTable))
+	return b[:]
+}
+
+type Adler32 struct{}
+
+func (Adler32) Sum(data []byte) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], adler32.Checksum(data))
+	return b[:]
+}
+
+// Fletcher16 is the classic two-sum-mod-255 checksum used by CDDI/FDDI.
+type Fletcher16 struct{}
+
+func (Fletcher16) Sum(data []byte) []byte {
+	var sum1, sum2 uint16
+	for _, b := range data {
+		sum1 = (sum1 + uint16(b)) % 255
+		sum2 = (sum2 + sum1) % 255
+	}
+	return []byte{byte(sum2), byte(sum1)}
+}
+
+// XOR is a one-byte checksum of all input bytes XORed together.
+type XOR struct{}
+
+func (XOR) Sum(data []byte) []byte {
+	var x byte
+	for _, b := range data {
+		x ^= b
+	}
+	return []byte{x}
+}
+
+type MD5 struct{}
+
+func (MD5) Sum(data []byte) []byte {
+	sum := md5.Sum(data)
+	return sum[:]
+}
+
+type SHA1 struct{}
+
+func (SHA1) Sum(data []byte) []byte {
+	sum := sha1.Sum(data)
+	return sum[:]
+}
+
+// Checksum encodes Over, then computes Algo over the encoded bytes and
+// writes it via StoredAs. On Parse it does the same over the bytes it reads
+// for Over and errors if the stored checksum doesn't match.
+type Checksum struct {
+	Algo     ChecksumAlgo
+	Over     Field
+	StoredAs Field
+}
+
+func (c Checksum) Parse(r io.Reader) (any, error) { return c.ParseCtx(r, nil) }
+func (c Checksum) Build(w io.Writer, v any) error { return c.BuildCtx(w, v, nil) }
+
+func (c Checksum) ParseCtx(r io.Reader, ctx *Ctx) (any, error) {
+	var captured bytes.Buffer
+	v, err := ctxParse(c.Over, io.TeeReader(r, &captured), ctx)
+	if err != nil {
+		return nil, err
+	}
+	expected := c.Algo.Sum(captured.Bytes())
+
+	storedVal, err := ctxParse(c.StoredAs, r, ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored, ok := checksumBytes(storedVal, len(expected))
+	if !ok {
+		return nil, fmt.Errorf("Checksum: StoredAs returned unsupported type %T", storedVal)
+	}
+	if !bytes.Equal(stored, expected) {
+		return nil, fmt.Errorf("Checksum: mismatch: stored %x, computed %x", stored, expected)
+	}
+	return v, nil
+}
+
+func (c Checksum) BuildCtx(w io.Writer, v any, ctx *Ctx) error {
+	var encoded bytes.Buffer
+	if err := ctxBuild(c.Over, &encoded, v, ctx); err != nil {
+		return err
+	}
+	if _, err := w.Write(encoded.Bytes()); err != nil {
+		return err
+	}
+	sum := c.Algo.Sum(encoded.Bytes())
+	storedVal, ok := checksumValue(sum, c.StoredAs)
+	if !ok {
+		return fmt.Errorf("Checksum: StoredAs field %T cannot hold a %d-byte checksum", c.StoredAs, len(sum))
+	}
+	return ctxBuild(c.StoredAs, w, storedVal, ctx)
+}
+
+// checksumBytes normalizes a value parsed by StoredAs (an unsigned integer
+// or a []byte) to big-endian bytes for comparison against Algo.Sum.
+func checksumBytes(v any, size int) ([]byte, bool) {
+	switch x := v.(type) {
+	case []byte:
+		if len(x) == size {
+			return x, true
+		}
+	case uint8:
+		if size == 1 {
+			return []byte{x}, true
+		}
+	case uint16:
+		if size == 2 {
+			b := make([]byte, 2)
+			binary.BigEndian.PutUint16(b, x)
+			return b, true
+		}
+	case uint32:
+		if size == 4 {
+			b := make([]byte, 4)
+			binary.BigEndian.PutUint32(b, x)
+			return b, true
+		}
+	case uint64:
+		if size == 8 {
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, x)
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// checksumValue converts a computed checksum's raw bytes into whatever
+// native type StoredAs.Build expects.
+func checksumValue(sum []byte, storedAs Field) (any, bool) {
+	switch storedAs.(type) {
+	case Bytes:
+		return sum, true
+	case Uint8:
+		if len(sum) == 1 {
+			return sum[0], true
+		}
+	case Uint16be, Uint16le:
+		if len(sum) == 2 {
+			return binary.BigEndian.Uint16(sum), true
+		}
+	case Uint32be, Uint32le:
+		if len(sum) == 4 {
+			return binary.BigEndian.Uint32(sum), true
+		}
+	case Uint64be:
+		if len(sum) == 8 {
+			return binary.BigEndian.Uint64(sum), true
+		}
+	}
+	return nil, false
+}
+
+// Computed fills a field's value from Provider on Build instead of the
+// caller-supplied value — e.g. a chunk length derived from a sibling that
+// was already built. On Parse it behaves exactly like Field, since the
+// bytes are genuinely present on the wire.
+type Computed struct {
+	Provider func(ctx *Ctx) any
+	Field    Field
+}
+
+func (c Computed) Parse(r io.Reader) (any, error) { return c.ParseCtx(r, nil) }
+func (c Computed) Build(w io.Writer, v any) error { return c.BuildCtx(w, v, nil) }
+
+func (c Computed) ParseCtx(r io.Reader, ctx *Ctx) (any, error) {
+	return ctxParse(c.Field, r, ctx)
+}
+
+func (c Computed) BuildCtx(w io.Writer, _ any, ctx *Ctx) error {
+	if c.Provider == nil {
+		return errors.New("Computed: Provider is nil")
+	}
+	return ctxBuild(c.Field, w, c.Provider(ctx), ctx)
+}
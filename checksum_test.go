@@ -0,0 +1,87 @@
+package construct
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestChecksum_RoundTrip(t *testing.T) {
+	field := Checksum{Algo: CRC32IEEE{}, Over: Uint32be{}, StoredAs: Uint32be{}}
+
+	var buf bytes.Buffer
+	if err := field.Build(&buf, uint32(1920)); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 8 {
+		t.Fatalf("expected 8 bytes (value + crc), got %d", buf.Len())
+	}
+
+	v, err := field.Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != uint32(1920) {
+		t.Errorf("got %v, want 1920", v)
+	}
+}
+
+func TestChecksum_MismatchDetected(t *testing.T) {
+	field := Checksum{Algo: CRC32IEEE{}, Over: Uint32be{}, StoredAs: Uint32be{}}
+
+	var buf bytes.Buffer
+	if err := field.Build(&buf, uint32(1920)); err != nil {
+		t.Fatal(err)
+	}
+	corrupted := buf.Bytes()
+	corrupted[0] ^= 0xFF
+
+	if _, err := field.Parse(bytes.NewReader(corrupted)); err == nil {
+		t.Error("expected checksum mismatch error")
+	}
+}
+
+func TestChecksum_ThreadsContextToOver(t *testing.T) {
+	s := Struct{
+		Named{Name: "len", Field: Uint8{}},
+		Checksum{
+			Algo:     CRC32IEEE{},
+			Over:     Prefixed{Length: "len", Inner: Bytes{Length: 3}},
+			StoredAs: Uint32be{},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := s.Build(&buf, []any{uint8(3), []byte("cat")}); err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := s.Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := values.([]any)
+	if !bytes.Equal(got[1].([]byte), []byte("cat")) {
+		t.Errorf("got %v, want \"cat\"", got[1])
+	}
+}
+
+func TestComputed_FillsLengthOnBuild(t *testing.T) {
+	s := Struct{
+		Computed{Provider: func(ctx *Ctx) any { return uint32(4) }, Field: Uint32be{}},
+		Uint32be{},
+	}
+
+	var buf bytes.Buffer
+	if err := s.Build(&buf, []any{nil, uint32(0xDEADBEEF)}); err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := s.Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := values.([]any)
+	if got[0] != uint32(4) {
+		t.Errorf("unexpected computed length: %v", got[0])
+	}
+}
@@ -10,6 +10,14 @@
 //   • Enum (named constants from integer values)
 //   • LengthPrefixedString (byte-length + data — perfect for protocols)
 //   • Padding (fixed zero bytes)
+//   • Varint / Uvarint (LEB128-style variable-length integers)
+//   • Marshal/Unmarshal — reflection-based codec driven by `construct:"..."` tags
+//   • Named/Switch/If/Prefixed — context-aware fields for back-references (see context.go)
+//   • BitStruct/BitField/BitFlag/BitPadding — sub-byte packed formats (see bitstruct.go)
+//   • Checksum/Computed — CRC/MD5/SHA1/etc. verified on Parse, computed on Build (see checksum.go)
+//   • Compile/Plan — cached fixed sizes and pooled buffers for hot parse loops (see plan.go)
+//   • Int/Float generics + NativeEndian variants for runtime-chosen byte order (see native.go)
+//   • RLP — Ethereum/devp2p Recursive Length Prefix encoding (see rlp.go)
 //   • Real-world PNG IHDR example in comments
 // Zero external dependencies. Simple, readable, perfect for humans + AI agents.
 // Use for network protocols, file formats, game saves, firmware, reverse engineering, IoT, security.
@@ -35,91 +43,119 @@ type Field interface {
 type Struct []Field
 
 func (s Struct) Parse(r io.Reader) (any, error) {
+	v, _, err := s.parseScoped(r, nil)
+	return v, err
+}
+
+// ParseCtx parses s as a child scope of parent, so Named fields inside s can
+// be addressed by fields outside it via a ".." path (see context.go).
+func (s Struct) ParseCtx(r io.Reader, parent *Ctx) (any, error) {
+	v, _, err := s.parseScoped(r, parent)
+	return v, err
+}
+
+func (s Struct) parseScoped(r io.Reader, parent *Ctx) (any, *Ctx, error) {
+	ctx := newCtx(parent)
 	values := make([]any, len(s))
 	for i, f := range s {
-		v, err := f.Parse(r)
+		v, err := ctxParse(f, r, ctx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		values[i] = v
 	}
-	return values, nil
+	return values, ctx, nil
 }
 
 func (s Struct) Build(w io.Writer, v any) error {
+	_, err := s.buildScoped(w, v, nil)
+	return err
+}
+
+// BuildCtx builds s as a child scope of parent, mirroring ParseCtx.
+func (s Struct) BuildCtx(w io.Writer, v any, parent *Ctx) error {
+	_, err := s.buildScoped(w, v, parent)
+	return err
+}
+
+func (s Struct) buildScoped(w io.Writer, v any, parent *Ctx) (*Ctx, error) {
 	values, ok := v.([]any)
 	if !ok {
-		return errors.New("struct requires []any value")
+		return nil, errors.New("struct requires []any value")
 	}
 	if len(values) != len(s) {
-		return errors.New("struct field/value count mismatch")
+		return nil, errors.New("struct field/value count mismatch")
 	}
+	ctx := newCtx(parent)
 	for i, f := range s {
-		if err := f.Build(w, values[i]); err != nil {
-			return err
+		if err := ctxBuild(f, w, values[i], ctx); err != nil {
+			return nil, err
 		}
 	}
-	return nil
+	return ctx, nil
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
-// Primitives — full set (all sizes, both endianness where useful)
+// Primitives — full set (all sizes, both endianness where useful). Each is a
+// thin wrapper around the generic Int/Float fields (see native.go), which do
+// the actual encode/decode work; these just pin a Size/Order/Signed combo
+// and keep Parse/Build's exact-type checks callers already depend on.
 // ─────────────────────────────────────────────────────────────────────────────
 type Int8 struct{}
-func (Int8) Parse(r io.Reader) (any, error) { var v int8; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Int8) Build(w io.Writer, v any) error { i, ok := v.(int8); if !ok { return errors.New("expected int8") }; return binary.Write(w, binary.BigEndian, i) }
+func (Int8) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 1, Order: binary.BigEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int8), nil }
+func (Int8) Build(w io.Writer, v any) error { i, ok := v.(int8); if !ok { return errors.New("expected int8") }; return (Int{Size: 1, Order: binary.BigEndian, Signed: true}).Build(w, i) }
 
 type Uint8 struct{}
-func (Uint8) Parse(r io.Reader) (any, error) { var v uint8; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Uint8) Build(w io.Writer, v any) error { i, ok := v.(uint8); if !ok { return errors.New("expected uint8") }; return binary.Write(w, binary.BigEndian, i) }
+func (Uint8) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 1, Order: binary.BigEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint8), nil }
+func (Uint8) Build(w io.Writer, v any) error { i, ok := v.(uint8); if !ok { return errors.New("expected uint8") }; return (Int{Size: 1, Order: binary.BigEndian}).Build(w, i) }
 
 type Int16be struct{}
-func (Int16be) Parse(r io.Reader) (any, error) { var v int16; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Int16be) Build(w io.Writer, v any) error { i, ok := v.(int16); if !ok { return errors.New("expected int16") }; return binary.Write(w, binary.BigEndian, i) }
+func (Int16be) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 2, Order: binary.BigEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int16), nil }
+func (Int16be) Build(w io.Writer, v any) error { i, ok := v.(int16); if !ok { return errors.New("expected int16") }; return (Int{Size: 2, Order: binary.BigEndian, Signed: true}).Build(w, i) }
 
 type Int16le struct{}
-func (Int16le) Parse(r io.Reader) (any, error) { var v int16; return v, binary.Read(r, binary.LittleEndian, &v) }
-func (Int16le) Build(w io.Writer, v any) error { i, ok := v.(int16); if !ok { return errors.New("expected int16") }; return binary.Write(w, binary.LittleEndian, i) }
+func (Int16le) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 2, Order: binary.LittleEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int16), nil }
+func (Int16le) Build(w io.Writer, v any) error { i, ok := v.(int16); if !ok { return errors.New("expected int16") }; return (Int{Size: 2, Order: binary.LittleEndian, Signed: true}).Build(w, i) }
 
 type Uint16be struct{}
-func (Uint16be) Parse(r io.Reader) (any, error) { var v uint16; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Uint16be) Build(w io.Writer, v any) error { i, ok := v.(uint16); if !ok { return errors.New("expected uint16") }; return binary.Write(w, binary.BigEndian, i) }
+func (Uint16be) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 2, Order: binary.BigEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint16), nil }
+func (Uint16be) Build(w io.Writer, v any) error { i, ok := v.(uint16); if !ok { return errors.New("expected uint16") }; return (Int{Size: 2, Order: binary.BigEndian}).Build(w, i) }
 
 type Uint16le struct{}
-func (Uint16le) Parse(r io.Reader) (any, error) { var v uint16; return v, binary.Read(r, binary.LittleEndian, &v) }
-func (Uint16le) Build(w io.Writer, v any) error { i, ok := v.(uint16); if !ok { return errors.New("expected uint16") }; return binary.Write(w, binary.LittleEndian, i) }
+func (Uint16le) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 2, Order: binary.LittleEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint16), nil }
+func (Uint16le) Build(w io.Writer, v any) error { i, ok := v.(uint16); if !ok { return errors.New("expected uint16") }; return (Int{Size: 2, Order: binary.LittleEndian}).Build(w, i) }
 
 type Int32be struct{}
-func (Int32be) Parse(r io.Reader) (any, error) { var v int32; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Int32be) Build(w io.Writer, v any) error { i, ok := v.(int32); if !ok { return errors.New("expected int32") }; return binary.Write(w, binary.BigEndian, i) }
+func (Int32be) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 4, Order: binary.BigEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int32), nil }
+func (Int32be) Build(w io.Writer, v any) error { i, ok := v.(int32); if !ok { return errors.New("expected int32") }; return (Int{Size: 4, Order: binary.BigEndian, Signed: true}).Build(w, i) }
 
 type Int32le struct{}
-func (Int32le) Parse(r io.Reader) (any, error) { var v int32; return v, binary.Read(r, binary.LittleEndian, &v) }
-func (Int32le) Build(w io.Writer, v any) error { i, ok := v.(int32); if !ok { return errors.New("expected int32") }; return binary.Write(w, binary.LittleEndian, i) }
+func (Int32le) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 4, Order: binary.LittleEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int32), nil }
+func (Int32le) Build(w io.Writer, v any) error { i, ok := v.(int32); if !ok { return errors.New("expected int32") }; return (Int{Size: 4, Order: binary.LittleEndian, Signed: true}).Build(w, i) }
 
 type Uint32be struct{}
-func (Uint32be) Parse(r io.Reader) (any, error) { var v uint32; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Uint32be) Build(w io.Writer, v any) error { i, ok := v.(uint32); if !ok { return errors.New("expected uint32") }; return binary.Write(w, binary.BigEndian, i) }
+func (Uint32be) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 4, Order: binary.BigEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint32), nil }
+func (Uint32be) Build(w io.Writer, v any) error { i, ok := v.(uint32); if !ok { return errors.New("expected uint32") }; return (Int{Size: 4, Order: binary.BigEndian}).Build(w, i) }
 
 type Uint32le struct{}
-func (Uint32le) Parse(r io.Reader) (any, error) { var v uint32; return v, binary.Read(r, binary.LittleEndian, &v) }
-func (Uint32le) Build(w io.Writer, v any) error { i, ok := v.(uint32); if !ok { return errors.New("expected uint32") }; return binary.Write(w, binary.LittleEndian, i) }
+func (Uint32le) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 4, Order: binary.LittleEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint32), nil }
+func (Uint32le) Build(w io.Writer, v any) error { i, ok := v.(uint32); if !ok { return errors.New("expected uint32") }; return (Int{Size: 4, Order: binary.LittleEndian}).Build(w, i) }
 
 type Int64be struct{}
-func (Int64be) Parse(r io.Reader) (any, error) { var v int64; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Int64be) Build(w io.Writer, v any) error { i, ok := v.(int64); if !ok { return errors.New("expected int64") }; return binary.Write(w, binary.BigEndian, i) }
+func (Int64be) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 8, Order: binary.BigEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int64), nil }
+func (Int64be) Build(w io.Writer, v any) error { i, ok := v.(int64); if !ok { return errors.New("expected int64") }; return (Int{Size: 8, Order: binary.BigEndian, Signed: true}).Build(w, i) }
 
 type Uint64be struct{}
-func (Uint64be) Parse(r io.Reader) (any, error) { var v uint64; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Uint64be) Build(w io.Writer, v any) error { i, ok := v.(uint64); if !ok { return errors.New("expected uint64") }; return binary.Write(w, binary.BigEndian, i) }
+func (Uint64be) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 8, Order: binary.BigEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint64), nil }
+func (Uint64be) Build(w io.Writer, v any) error { i, ok := v.(uint64); if !ok { return errors.New("expected uint64") }; return (Int{Size: 8, Order: binary.BigEndian}).Build(w, i) }
 
 type Float32be struct{}
-func (Float32be) Parse(r io.Reader) (any, error) { var v float32; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Float32be) Build(w io.Writer, v any) error { f, ok := v.(float32); if !ok { return errors.New("expected float32") }; return binary.Write(w, binary.BigEndian, f) }
+func (Float32be) Parse(r io.Reader) (any, error) { v, err := (Float{Size: 4, Order: binary.BigEndian}).Parse(r); if err != nil { return nil, err }; return v.(float32), nil }
+func (Float32be) Build(w io.Writer, v any) error { f, ok := v.(float32); if !ok { return errors.New("expected float32") }; return (Float{Size: 4, Order: binary.BigEndian}).Build(w, f) }
 
 type Float64be struct{}
-func (Float64be) Parse(r io.Reader) (any, error) { var v float64; return v, binary.Read(r, binary.BigEndian, &v) }
-func (Float64be) Build(w io.Writer, v any) error { f, ok := v.(float64); if !ok { return errors.New("expected float64") }; return binary.Write(w, binary.BigEndian, f) }
+func (Float64be) Parse(r io.Reader) (any, error) { v, err := (Float{Size: 8, Order: binary.BigEndian}).Parse(r); if err != nil { return nil, err }; return v.(float64), nil }
+func (Float64be) Build(w io.Writer, v any) error { f, ok := v.(float64); if !ok { return errors.New("expected float64") }; return (Float{Size: 8, Order: binary.BigEndian}).Build(w, f) }
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Bytes & String (fixed length)
@@ -162,14 +198,23 @@ func (s String) Build(w io.Writer, v any) error {
 // ─────────────────────────────────────────────────────────────────────────────
 // Array — fixed count of any sub-field
 // ─────────────────────────────────────────────────────────────────────────────
+// Count is a Ref so an Array's length can be a literal int (most cases) or a
+// back-reference like "../header/count" resolved through parse/build context
+// — see context.go.
 type Array struct {
-	Count int
+	Count Ref
 	Field Field
 }
-func (a Array) Parse(r io.Reader) (any, error) {
-	values := make([]any, a.Count)
-	for i := 0; i < a.Count; i++ {
-		v, err := a.Field.Parse(r)
+func (a Array) Parse(r io.Reader) (any, error) { return a.ParseCtx(r, nil) }
+func (a Array) Build(w io.Writer, v any) error { return a.BuildCtx(w, v, nil) }
+func (a Array) ParseCtx(r io.Reader, ctx *Ctx) (any, error) {
+	count, err := resolveRef(a.Count, ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, count)
+	for i := 0; i < count; i++ {
+		v, err := ctxParse(a.Field, r, ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -177,13 +222,17 @@ func (a Array) Parse(r io.Reader) (any, error) {
 	}
 	return values, nil
 }
-func (a Array) Build(w io.Writer, v any) error {
+func (a Array) BuildCtx(w io.Writer, v any, ctx *Ctx) error {
+	count, err := resolveRef(a.Count, ctx)
+	if err != nil {
+		return err
+	}
 	values, ok := v.([]any)
-	if !ok || len(values) != a.Count {
+	if !ok || len(values) != count {
 		return errors.New("Array: value must be []any of correct length")
 	}
 	for _, val := range values {
-		if err := a.Field.Build(w, val); err != nil {
+		if err := ctxBuild(a.Field, w, val, ctx); err != nil {
 			return err
 		}
 	}
@@ -294,47 +343,56 @@ func (p Padding) Build(w io.Writer, _ any) error {
 // REAL-WORLD EXAMPLE: PNG IHDR chunk (copy-paste ready)
 // ─────────────────────────────────────────────────────────────────────────────
 /*
-	// Full PNG IHDR parser (signature + chunk)
+	// Full PNG IHDR parser (signature + chunk). The CRC is a real, verified
+	// round-trip via Checksum — not a hardcoded placeholder.
 	pngHeader := construct.Struct{
 		construct.Const{Value: []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}}, // PNG signature
-		construct.Uint32be{}, // chunk length
-		construct.Const{Value: []byte{'I','H','D','R'}}, // chunk type
-		construct.Uint32be{}, // width
-		construct.Uint32be{}, // height
-		construct.Uint8{},    // bit depth
-		construct.Enum{       // color type
-			SubField: construct.Uint8{},
-			Mapping: map[int64]string{
-				0: "Grayscale",
-				2: "Truecolor",
-				3: "Indexed",
-				4: "Grayscale+Alpha",
-				6: "Truecolor+Alpha",
+		construct.Uint32be{}, // chunk length (always 13 for IHDR)
+		construct.Checksum{
+			Algo: construct.CRC32IEEE{},
+			Over: construct.Struct{
+				construct.Const{Value: []byte{'I', 'H', 'D', 'R'}}, // chunk type
+				construct.Uint32be{}, // width
+				construct.Uint32be{}, // height
+				construct.Uint8{},    // bit depth
+				construct.Enum{       // color type
+					SubField: construct.Uint8{},
+					Mapping: map[int64]string{
+						0: "Grayscale",
+						2: "Truecolor",
+						3: "Indexed",
+						4: "Grayscale+Alpha",
+						6: "Truecolor+Alpha",
+					},
+				},
+				construct.Uint8{}, // compression method
+				construct.Uint8{}, // filter method
+				construct.Uint8{}, // interlace method
 			},
+			StoredAs: construct.Uint32be{}, // CRC, computed on Build / verified on Parse
 		},
-		construct.Uint8{},    // compression method
-		construct.Uint8{},    // filter method
-		construct.Uint8{},    // interlace method
-		construct.Uint32be{}, // CRC
 	}
 
 	// Parse
 	values, err := pngHeader.Parse(bytes.NewReader(pngData))
-	// values[0] = signature bytes, values[3] = width, values[5] = "Truecolor", etc.
+	// values[0] = signature bytes, values[1] = length, values[2] = the Over struct's
+	// []any ([1] = width, [4] = "Truecolor", etc.) — and err is non-nil if the CRC
+	// stored in the file doesn't match what's actually in the chunk.
 
 	// Build (reverse)
 	var buf bytes.Buffer
 	pngHeader.Build(&buf, []any{
 		nil, // Const ignores value
 		uint32(13), // length
-		nil, // Const
-		uint32(1920),
-		uint32(1080),
-		uint8(8),
-		int64(6), // Truecolor+Alpha
-		uint8(0),
-		uint8(0),
-		uint8(0),
-		uint32(0x12345678), // CRC
+		[]any{
+			nil, // Const
+			uint32(1920),
+			uint32(1080),
+			uint8(8),
+			uint8(6), // Truecolor+Alpha
+			uint8(0),
+			uint8(0),
+			uint8(0),
+		}, // Checksum computes and writes the CRC itself
 	})
 */
@@ -0,0 +1,286 @@
+package construct
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Context-aware fields — Switch, If, Prefixed, and a Ref-driven Array let one
+// field's shape depend on a sibling's already-decoded value, which is the
+// single biggest gap for real protocols (DNS, TLS records, ELF, MP4).
+//
+// A Field that needs sibling values implements CtxField in addition to Field;
+// Struct threads a *Ctx through its children and falls back to plain
+// Parse/Build for fields that don't care about context. Named{Name, Field}
+// is how a value gets published into that context so later siblings (or
+// nested structs, via a "../" path) can refer back to it.
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Ctx is one Struct's parse/build scope: the named values decoded (or about
+// to be built) so far in that Struct, plus a link to the enclosing scope so
+// a Ref path can walk up with "..".
+type Ctx struct {
+	values   map[string]any
+	children map[string]*Ctx
+	parent   *Ctx
+}
+
+func newCtx(parent *Ctx) *Ctx {
+	return &Ctx{values: map[string]any{}, parent: parent}
+}
+
+func (c *Ctx) set(name string, v any) {
+	c.values[name] = v
+}
+
+func (c *Ctx) setChild(name string, child *Ctx) {
+	if c.children == nil {
+		c.children = map[string]*Ctx{}
+	}
+	c.children[name] = child
+}
+
+// Resolve looks up a "/"-separated path such as "len" or "../header/len".
+// Leading ".." segments walk to the enclosing scope; the remaining segments
+// walk into scopes published by Named fields, ending on a plain value.
+func (c *Ctx) Resolve(path string) (any, bool) {
+	cur := c
+	segs := strings.Split(path, "/")
+	for len(segs) > 0 && segs[0] == ".." {
+		if cur == nil || cur.parent == nil {
+			return nil, false
+		}
+		cur = cur.parent
+		segs = segs[1:]
+	}
+	if cur == nil || len(segs) == 0 {
+		return nil, false
+	}
+	for len(segs) > 1 {
+		child, ok := cur.children[segs[0]]
+		if !ok {
+			return nil, false
+		}
+		cur = child
+		segs = segs[1:]
+	}
+	v, ok := cur.values[segs[0]]
+	return v, ok
+}
+
+// Ref is either a literal int or a Ctx path string (e.g. "../header/len").
+// It's used anywhere a size/count/tag may be a back-reference instead of a
+// constant, such as Array.Count or Prefixed.Length.
+type Ref any
+
+// resolveRef turns a Ref into a concrete int, resolving a path Ref against
+// ctx. ctx may be nil, in which case only literal ints are accepted.
+func resolveRef(ref Ref, ctx *Ctx) (int, error) {
+	switch r := ref.(type) {
+	case int:
+		return r, nil
+	case string:
+		if ctx == nil {
+			return 0, fmt.Errorf("construct: ref %q needs a context but none was supplied", r)
+		}
+		v, ok := ctx.Resolve(r)
+		if !ok {
+			return 0, fmt.Errorf("construct: cannot resolve ref %q", r)
+		}
+		n, ok := toInt64(v)
+		if !ok {
+			return 0, fmt.Errorf("construct: ref %q resolved to non-integer %T", r, v)
+		}
+		return int(n), nil
+	default:
+		return 0, fmt.Errorf("construct: invalid ref %v (%T)", ref, ref)
+	}
+}
+
+// CtxField is implemented by fields whose Parse/Build needs access to sibling
+// values decoded earlier in the same Struct. Fields that only implement Field
+// keep working unmodified: Struct falls back to plain Parse/Build for them.
+type CtxField interface {
+	ParseCtx(r io.Reader, ctx *Ctx) (any, error)
+	BuildCtx(w io.Writer, v any, ctx *Ctx) error
+}
+
+// ctxParse dispatches to f.ParseCtx when f is context-aware, else f.Parse.
+func ctxParse(f Field, r io.Reader, ctx *Ctx) (any, error) {
+	if cf, ok := f.(CtxField); ok {
+		return cf.ParseCtx(r, ctx)
+	}
+	return f.Parse(r)
+}
+
+// ctxBuild dispatches to f.BuildCtx when f is context-aware, else f.Build.
+func ctxBuild(f Field, w io.Writer, v any, ctx *Ctx) error {
+	if cf, ok := f.(CtxField); ok {
+		return cf.BuildCtx(w, v, ctx)
+	}
+	return f.Build(w, v)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Named — publishes its decoded/built value into the enclosing Ctx under
+// Name, so a later sibling's Ref can read it back (e.g. a Named
+// "header_len" ahead of a Prefixed whose Length is "header_len").
+// ─────────────────────────────────────────────────────────────────────────────
+type Named struct {
+	Name  string
+	Field Field
+}
+
+func (n Named) Parse(r io.Reader) (any, error) { return n.ParseCtx(r, newCtx(nil)) }
+func (n Named) Build(w io.Writer, v any) error { return n.BuildCtx(w, v, newCtx(nil)) }
+
+func (n Named) ParseCtx(r io.Reader, ctx *Ctx) (any, error) {
+	if st, ok := n.Field.(Struct); ok {
+		v, child, err := st.parseScoped(r, ctx)
+		if err != nil {
+			return nil, err
+		}
+		ctx.set(n.Name, v)
+		ctx.setChild(n.Name, child)
+		return v, nil
+	}
+	v, err := ctxParse(n.Field, r, ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx.set(n.Name, v)
+	return v, nil
+}
+
+func (n Named) BuildCtx(w io.Writer, v any, ctx *Ctx) error {
+	if st, ok := n.Field.(Struct); ok {
+		child, err := st.buildScoped(w, v, ctx)
+		if err != nil {
+			return err
+		}
+		ctx.set(n.Name, v)
+		ctx.setChild(n.Name, child)
+		return nil
+	}
+	if err := ctxBuild(n.Field, w, v, ctx); err != nil {
+		return err
+	}
+	ctx.set(n.Name, v)
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Switch — a tagged union: Key picks which of Cases to parse/build, falling
+// back to Default (nil Default is an error on an unmatched key).
+// ─────────────────────────────────────────────────────────────────────────────
+type Switch struct {
+	Key     func(ctx *Ctx) any
+	Cases   map[any]Field
+	Default Field
+}
+
+func (s Switch) Parse(r io.Reader) (any, error) { return s.ParseCtx(r, newCtx(nil)) }
+func (s Switch) Build(w io.Writer, v any) error { return s.BuildCtx(w, v, newCtx(nil)) }
+
+func (s Switch) pick(ctx *Ctx) (Field, error) {
+	key := s.Key(ctx)
+	if f, ok := s.Cases[key]; ok {
+		return f, nil
+	}
+	if s.Default != nil {
+		return s.Default, nil
+	}
+	return nil, fmt.Errorf("construct: Switch: no case for key %v", key)
+}
+
+func (s Switch) ParseCtx(r io.Reader, ctx *Ctx) (any, error) {
+	f, err := s.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ctxParse(f, r, ctx)
+}
+
+func (s Switch) BuildCtx(w io.Writer, v any, ctx *Ctx) error {
+	f, err := s.pick(ctx)
+	if err != nil {
+		return err
+	}
+	return ctxBuild(f, w, v, ctx)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// If — a conditional field; Else may be nil to mean "nothing to parse/build".
+// ─────────────────────────────────────────────────────────────────────────────
+type If struct {
+	Cond func(ctx *Ctx) bool
+	Then Field
+	Else Field
+}
+
+func (i If) Parse(r io.Reader) (any, error) { return i.ParseCtx(r, newCtx(nil)) }
+func (i If) Build(w io.Writer, v any) error { return i.BuildCtx(w, v, newCtx(nil)) }
+
+func (i If) ParseCtx(r io.Reader, ctx *Ctx) (any, error) {
+	if i.Cond(ctx) {
+		return ctxParse(i.Then, r, ctx)
+	}
+	if i.Else == nil {
+		return nil, nil
+	}
+	return ctxParse(i.Else, r, ctx)
+}
+
+func (i If) BuildCtx(w io.Writer, v any, ctx *Ctx) error {
+	if i.Cond(ctx) {
+		return ctxBuild(i.Then, w, v, ctx)
+	}
+	if i.Else == nil {
+		return nil
+	}
+	return ctxBuild(i.Else, w, v, ctx)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Prefixed — Inner occupies exactly Length bytes, where Length is usually a
+// back-reference to a sibling field rather than a literal (an inline
+// self-describing prefix is just Length: Inner's own size).
+// ─────────────────────────────────────────────────────────────────────────────
+type Prefixed struct {
+	Length Ref
+	Inner  Field
+}
+
+func (p Prefixed) Parse(r io.Reader) (any, error) { return p.ParseCtx(r, nil) }
+func (p Prefixed) Build(w io.Writer, v any) error { return p.BuildCtx(w, v, nil) }
+
+func (p Prefixed) ParseCtx(r io.Reader, ctx *Ctx) (any, error) {
+	n, err := resolveRef(p.Length, ctx)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return ctxParse(p.Inner, bytes.NewReader(buf), ctx)
+}
+
+func (p Prefixed) BuildCtx(w io.Writer, v any, ctx *Ctx) error {
+	n, err := resolveRef(p.Length, ctx)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := ctxBuild(p.Inner, &buf, v, ctx); err != nil {
+		return err
+	}
+	if buf.Len() != n {
+		return fmt.Errorf("construct: Prefixed: built %d bytes, want %d", buf.Len(), n)
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,72 @@
+package construct
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrefixed_BackReference(t *testing.T) {
+	// header_len tells Prefixed how many bytes of body to consume.
+	s := Struct{
+		Named{Name: "header_len", Field: Uint8{}},
+		Prefixed{Length: "header_len", Inner: String{Length: 4}},
+	}
+	data := []byte{0x04, 'T', 'e', 's', 't'}
+	values, err := s.Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := values.([]any)
+	if got[1] != "Test" {
+		t.Errorf("unexpected body: %v", got[1])
+	}
+}
+
+func TestSwitch_TaggedUnion(t *testing.T) {
+	s := Struct{
+		Named{Name: "kind", Field: Uint8{}},
+		Switch{
+			Key: func(ctx *Ctx) any {
+				v, _ := ctx.Resolve("kind")
+				return v
+			},
+			Cases: map[any]Field{
+				uint8(0): Uint32be{},
+				uint8(1): String{Length: 4},
+			},
+		},
+	}
+
+	data := []byte{0x01, 'T', 'e', 's', 't'}
+	values, err := s.Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := values.([]any)
+	if got[1] != "Test" {
+		t.Errorf("unexpected value: %v", got[1])
+	}
+}
+
+func TestIf_Conditional(t *testing.T) {
+	s := Struct{
+		Named{Name: "hasBody", Field: Uint8{}},
+		If{
+			Cond: func(ctx *Ctx) bool {
+				v, _ := ctx.Resolve("hasBody")
+				return v.(uint8) != 0
+			},
+			Then: Uint32be{},
+		},
+	}
+
+	data := []byte{0x00}
+	values, err := s.Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := values.([]any)
+	if got[1] != nil {
+		t.Errorf("expected nil when condition is false, got %v", got[1])
+	}
+}
@@ -0,0 +1,173 @@
+package construct
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Int/Float — generic, endianness-parameterized primitives. Every concrete
+// primitive in construct.go (Int16be, Uint32le, ...) is a thin wrapper around one of
+// these. Use them directly when the byte order isn't known until runtime —
+// e.g. parsing in-memory kernel/eBPF structures, shared-memory IPC, or
+// mmap'd on-disk formats that match host byte order (binary.NativeEndian,
+// wired up below as the *ne fields).
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Int is a Size-byte integer (1, 2, 4, or 8) in Order byte order, Signed or
+// not. Build accepts any Go integer type, coercing it via reflection.
+type Int struct {
+	Size   int
+	Order  binary.ByteOrder
+	Signed bool
+}
+
+func (i Int) Parse(r io.Reader) (any, error) {
+	switch {
+	case i.Size == 1 && i.Signed:
+		var v int8
+		return v, binary.Read(r, i.Order, &v)
+	case i.Size == 1:
+		var v uint8
+		return v, binary.Read(r, i.Order, &v)
+	case i.Size == 2 && i.Signed:
+		var v int16
+		return v, binary.Read(r, i.Order, &v)
+	case i.Size == 2:
+		var v uint16
+		return v, binary.Read(r, i.Order, &v)
+	case i.Size == 4 && i.Signed:
+		var v int32
+		return v, binary.Read(r, i.Order, &v)
+	case i.Size == 4:
+		var v uint32
+		return v, binary.Read(r, i.Order, &v)
+	case i.Size == 8 && i.Signed:
+		var v int64
+		return v, binary.Read(r, i.Order, &v)
+	case i.Size == 8:
+		var v uint64
+		return v, binary.Read(r, i.Order, &v)
+	default:
+		return nil, fmt.Errorf("construct: Int: unsupported size %d", i.Size)
+	}
+}
+
+func (i Int) Build(w io.Writer, v any) error {
+	n, ok := toInt64(v)
+	if !ok {
+		return errors.New("Int: expected an integer value")
+	}
+	switch {
+	case i.Size == 1 && i.Signed:
+		return binary.Write(w, i.Order, int8(n))
+	case i.Size == 1:
+		return binary.Write(w, i.Order, uint8(n))
+	case i.Size == 2 && i.Signed:
+		return binary.Write(w, i.Order, int16(n))
+	case i.Size == 2:
+		return binary.Write(w, i.Order, uint16(n))
+	case i.Size == 4 && i.Signed:
+		return binary.Write(w, i.Order, int32(n))
+	case i.Size == 4:
+		return binary.Write(w, i.Order, uint32(n))
+	case i.Size == 8 && i.Signed:
+		return binary.Write(w, i.Order, n)
+	case i.Size == 8:
+		return binary.Write(w, i.Order, uint64(n))
+	default:
+		return fmt.Errorf("construct: Int: unsupported size %d", i.Size)
+	}
+}
+
+func (i Int) FixedSize() (int, bool) { return i.Size, true }
+
+// Float is a Size-byte float (4 or 8) in Order byte order. Build accepts
+// either float32 or float64, converting as needed.
+type Float struct {
+	Size  int
+	Order binary.ByteOrder
+}
+
+func (f Float) Parse(r io.Reader) (any, error) {
+	switch f.Size {
+	case 4:
+		var v float32
+		return v, binary.Read(r, f.Order, &v)
+	case 8:
+		var v float64
+		return v, binary.Read(r, f.Order, &v)
+	default:
+		return nil, fmt.Errorf("construct: Float: unsupported size %d", f.Size)
+	}
+}
+
+func (f Float) Build(w io.Writer, v any) error {
+	switch f.Size {
+	case 4:
+		x, ok := toFloat64(v)
+		if !ok {
+			return errors.New("Float: expected a float value")
+		}
+		return binary.Write(w, f.Order, float32(x))
+	case 8:
+		x, ok := toFloat64(v)
+		if !ok {
+			return errors.New("Float: expected a float value")
+		}
+		return binary.Write(w, f.Order, x)
+	default:
+		return fmt.Errorf("construct: Float: unsupported size %d", f.Size)
+	}
+}
+
+func (f Float) FixedSize() (int, bool) { return f.Size, true }
+
+func toFloat64(v any) (float64, bool) {
+	switch x := v.(type) {
+	case float32:
+		return float64(x), true
+	case float64:
+		return x, true
+	default:
+		return 0, false
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// NativeEndian variants — for layouts that match host byte order rather than
+// a protocol-mandated one, the way the stdlib's binary.NativeEndian does.
+// ─────────────────────────────────────────────────────────────────────────────
+type Int16ne struct{}
+func (Int16ne) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 2, Order: binary.NativeEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int16), nil }
+func (Int16ne) Build(w io.Writer, v any) error { i, ok := v.(int16); if !ok { return errors.New("expected int16") }; return (Int{Size: 2, Order: binary.NativeEndian, Signed: true}).Build(w, i) }
+
+type Uint16ne struct{}
+func (Uint16ne) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 2, Order: binary.NativeEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint16), nil }
+func (Uint16ne) Build(w io.Writer, v any) error { i, ok := v.(uint16); if !ok { return errors.New("expected uint16") }; return (Int{Size: 2, Order: binary.NativeEndian}).Build(w, i) }
+
+type Int32ne struct{}
+func (Int32ne) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 4, Order: binary.NativeEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int32), nil }
+func (Int32ne) Build(w io.Writer, v any) error { i, ok := v.(int32); if !ok { return errors.New("expected int32") }; return (Int{Size: 4, Order: binary.NativeEndian, Signed: true}).Build(w, i) }
+
+type Uint32ne struct{}
+func (Uint32ne) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 4, Order: binary.NativeEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint32), nil }
+func (Uint32ne) Build(w io.Writer, v any) error { i, ok := v.(uint32); if !ok { return errors.New("expected uint32") }; return (Int{Size: 4, Order: binary.NativeEndian}).Build(w, i) }
+
+type Int64ne struct{}
+func (Int64ne) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 8, Order: binary.NativeEndian, Signed: true}).Parse(r); if err != nil { return nil, err }; return v.(int64), nil }
+func (Int64ne) Build(w io.Writer, v any) error { i, ok := v.(int64); if !ok { return errors.New("expected int64") }; return (Int{Size: 8, Order: binary.NativeEndian, Signed: true}).Build(w, i) }
+
+type Uint64ne struct{}
+func (Uint64ne) Parse(r io.Reader) (any, error) { v, err := (Int{Size: 8, Order: binary.NativeEndian}).Parse(r); if err != nil { return nil, err }; return v.(uint64), nil }
+func (Uint64ne) Build(w io.Writer, v any) error { i, ok := v.(uint64); if !ok { return errors.New("expected uint64") }; return (Int{Size: 8, Order: binary.NativeEndian}).Build(w, i) }
+
+type Float32ne struct{}
+func (Float32ne) Parse(r io.Reader) (any, error) { v, err := (Float{Size: 4, Order: binary.NativeEndian}).Parse(r); if err != nil { return nil, err }; return v.(float32), nil }
+func (Float32ne) Build(w io.Writer, v any) error { f, ok := v.(float32); if !ok { return errors.New("expected float32") }; return (Float{Size: 4, Order: binary.NativeEndian}).Build(w, f) }
+
+type Float64ne struct{}
+func (Float64ne) Parse(r io.Reader) (any, error) { v, err := (Float{Size: 8, Order: binary.NativeEndian}).Parse(r); if err != nil { return nil, err }; return v.(float64), nil }
+func (Float64ne) Build(w io.Writer, v any) error { f, ok := v.(float64); if !ok { return errors.New("expected float64") }; return (Float{Size: 8, Order: binary.NativeEndian}).Build(w, f) }
@@ -0,0 +1,54 @@
+package construct
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestInt_RuntimeEndianness(t *testing.T) {
+	le := Int{Size: 4, Order: binary.LittleEndian}
+	var buf bytes.Buffer
+	if err := le.Build(&buf, 0x01020304); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0x04, 0x03, 0x02, 0x01}) {
+		t.Errorf("unexpected encoding: %x", buf.Bytes())
+	}
+	v, err := le.Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != uint32(0x01020304) {
+		t.Errorf("got %v, want 0x01020304", v)
+	}
+}
+
+func TestFloat_Generic(t *testing.T) {
+	f := Float{Size: 8, Order: binary.BigEndian}
+	var buf bytes.Buffer
+	if err := f.Build(&buf, float32(3.5)); err != nil {
+		t.Fatal(err)
+	}
+	v, err := f.Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != float64(3.5) {
+		t.Errorf("got %v, want 3.5", v)
+	}
+}
+
+func TestUint32ne_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (Uint32ne{}).Build(&buf, uint32(42)); err != nil {
+		t.Fatal(err)
+	}
+	v, err := (Uint32ne{}).Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != uint32(42) {
+		t.Errorf("got %v, want 42", v)
+	}
+}
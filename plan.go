@@ -0,0 +1,181 @@
+package construct
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Compile/Plan — a performance path for the common case of parsing many
+// instances of the same fixed-size shape (e.g. a slice of repeated structs
+// read one at a time off a socket or file). Compile walks the field tree
+// once and caches each node's byte size via FixedSize; a Plan then reads
+// exactly that many bytes into a pooled backing array and a pooled
+// bytes.Reader instead of allocating a fresh []byte and reader per call.
+// That doesn't change what each primitive's own binary.Read allocates
+// internally, but it removes the per-call buffering allocation a caller
+// would otherwise pay to carve one record at a time out of a stream. Fields
+// with no fixed size (variable strings, varints, Switch/If) fall back to
+// the normal streaming Parse. Build does not depend on the size: it always
+// encodes into the pooled buffer and writes the result in one call.
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Sized is implemented by fields whose encoded size doesn't depend on the
+// value being encoded. Compile uses it to decide whether a node can be read
+// into a pooled buffer up front; fields that don't implement it (or whose
+// size does depend on context, like Switch or a ref-counted Array) are
+// simply treated as variable-size.
+type Sized interface {
+	FixedSize() (int, bool)
+}
+
+// fixedSizeOf is the Sized lookup Compile and nested FixedSize methods use;
+// a field with no FixedSize method is treated as variable-size.
+func fixedSizeOf(f Field) (int, bool) {
+	if s, ok := f.(Sized); ok {
+		return s.FixedSize()
+	}
+	return 0, false
+}
+
+func (Int8) FixedSize() (int, bool)      { return 1, true }
+func (Uint8) FixedSize() (int, bool)     { return 1, true }
+func (Int16be) FixedSize() (int, bool)   { return 2, true }
+func (Int16le) FixedSize() (int, bool)   { return 2, true }
+func (Uint16be) FixedSize() (int, bool)  { return 2, true }
+func (Uint16le) FixedSize() (int, bool)  { return 2, true }
+func (Int32be) FixedSize() (int, bool)   { return 4, true }
+func (Int32le) FixedSize() (int, bool)   { return 4, true }
+func (Uint32be) FixedSize() (int, bool)  { return 4, true }
+func (Uint32le) FixedSize() (int, bool)  { return 4, true }
+func (Int64be) FixedSize() (int, bool)   { return 8, true }
+func (Uint64be) FixedSize() (int, bool)  { return 8, true }
+func (Float32be) FixedSize() (int, bool) { return 4, true }
+func (Float64be) FixedSize() (int, bool) { return 8, true }
+
+func (b Bytes) FixedSize() (int, bool) { return b.Length, true }
+func (s String) FixedSize() (int, bool) { return s.Length, true }
+func (c Const) FixedSize() (int, bool) { return len(c.Value), true }
+func (p Padding) FixedSize() (int, bool) { return p.Length, true }
+func (e Enum) FixedSize() (int, bool) { return fixedSizeOf(e.SubField) }
+
+// Uvarint/Varint and LengthPrefixedString have no FixedSize method: their
+// encoded length depends on the value, so fixedSizeOf correctly reports them
+// as variable-size by falling through to its default case.
+
+func (s Struct) FixedSize() (int, bool) {
+	total := 0
+	for _, f := range s {
+		sz, ok := fixedSizeOf(f)
+		if !ok {
+			return 0, false
+		}
+		total += sz
+	}
+	return total, true
+}
+
+func (a Array) FixedSize() (int, bool) {
+	count, ok := a.Count.(int)
+	if !ok {
+		return 0, false
+	}
+	sz, ok := fixedSizeOf(a.Field)
+	if !ok {
+		return 0, false
+	}
+	return count * sz, true
+}
+
+func (n Named) FixedSize() (int, bool) { return fixedSizeOf(n.Field) }
+
+func (s BitStruct) FixedSize() (int, bool) {
+	total := s.totalBits()
+	if total%8 != 0 {
+		return 0, false
+	}
+	return total / 8, true
+}
+
+func (c Checksum) FixedSize() (int, bool) {
+	overSize, ok := fixedSizeOf(c.Over)
+	if !ok {
+		return 0, false
+	}
+	storedSize, ok := fixedSizeOf(c.StoredAs)
+	if !ok {
+		return 0, false
+	}
+	return overSize + storedSize, true
+}
+
+func (c Computed) FixedSize() (int, bool) { return fixedSizeOf(c.Field) }
+
+// scratch is the pooled state behind a Plan: a backing array sized to the
+// compiled field and a bytes.Reader reused across calls so a hot parse loop
+// over many repeated structs doesn't allocate fresh reader state every
+// call. Build reuses the backing array as its buffer's storage but wraps it
+// in a new bytes.Buffer each call.
+type scratch struct {
+	buf []byte
+	rdr bytes.Reader
+}
+
+// Plan is a compiled field tree: a fixed byte size cached up front (when
+// possible) plus a pool of scratch buffers shared across Parse/Build calls.
+type Plan struct {
+	field Field
+	size  int
+	fixed bool
+	pool  sync.Pool
+}
+
+// Compile walks f once and caches its size via FixedSize, so repeated
+// Parse/Build calls against the same shape (e.g. parsing a slice of
+// identical structs) can reuse a pooled buffer instead of allocating fresh
+// reader/writer state every time.
+func Compile(f Field) *Plan {
+	size, fixed := fixedSizeOf(f)
+	return &Plan{
+		field: f,
+		size:  size,
+		fixed: fixed,
+		pool:  sync.Pool{New: func() any { return new(scratch) }},
+	}
+}
+
+// Parse reads one instance of the compiled field from r.
+func (p *Plan) Parse(r io.Reader) (any, error) {
+	if !p.fixed {
+		return p.field.Parse(r)
+	}
+	sc := p.pool.Get().(*scratch)
+	defer p.pool.Put(sc)
+	if cap(sc.buf) < p.size {
+		sc.buf = make([]byte, p.size)
+	} else {
+		sc.buf = sc.buf[:p.size]
+	}
+	if _, err := io.ReadFull(r, sc.buf); err != nil {
+		return nil, err
+	}
+	sc.rdr.Reset(sc.buf)
+	return p.field.Parse(&sc.rdr)
+}
+
+// Build writes one instance of the compiled field to w.
+func (p *Plan) Build(w io.Writer, v any) error {
+	sc := p.pool.Get().(*scratch)
+	defer p.pool.Put(sc)
+	buf := bytes.NewBuffer(sc.buf[:0])
+	if err := p.field.Build(buf, v); err != nil {
+		return err
+	}
+	sc.buf = buf.Bytes()
+	_, err := w.Write(sc.buf)
+	return err
+}
+
+// FixedSize reports the compiled field's byte size, mirroring Sized.
+func (p *Plan) FixedSize() (int, bool) { return p.size, p.fixed }
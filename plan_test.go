@@ -0,0 +1,75 @@
+package construct
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// ihdrLike mirrors the PNG IHDR body: width, height, bit depth, color type.
+var ihdrLike = Struct{Uint32be{}, Uint32be{}, Uint8{}, Uint8{}}
+
+func TestCompile_FixedSize(t *testing.T) {
+	plan := Compile(ihdrLike)
+	size, fixed := plan.FixedSize()
+	if !fixed || size != 10 {
+		t.Fatalf("got (%d, %v), want (10, true)", size, fixed)
+	}
+}
+
+func TestCompile_RoundTrip(t *testing.T) {
+	plan := Compile(ihdrLike)
+	var buf bytes.Buffer
+	in := []any{uint32(1920), uint32(1080), uint8(8), uint8(6)}
+	if err := plan.Build(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+	v, err := plan.Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.([]any)
+	if got[0] != in[0] || got[1] != in[1] || got[2] != in[2] || got[3] != in[3] {
+		t.Errorf("unexpected round trip: %v", got)
+	}
+}
+
+// Both benchmarks parse the same record repeatedly off one continuous
+// io.Reader, the way a caller reading a slice of repeated structs off a
+// file or socket actually would — not off an already-in-memory
+// bytes.Reader per record, which needs no buffering either way.
+func repeatedRecords(n int) io.Reader {
+	var buf bytes.Buffer
+	for i := 0; i < n; i++ {
+		ihdrLike.Build(&buf, []any{uint32(1920), uint32(1080), uint8(8), uint8(6)})
+	}
+	return &buf
+}
+
+// BenchmarkParse_NaivePerRecord is what Compile/Plan replaces: the caller
+// has to carve each fixed-size record out of the stream by hand before
+// handing it to Parse.
+func BenchmarkParse_NaivePerRecord(b *testing.B) {
+	r := repeatedRecords(b.N)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rec := make([]byte, 10)
+		if _, err := io.ReadFull(r, rec); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := ihdrLike.Parse(bytes.NewReader(rec)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParse_Plan(b *testing.B) {
+	r := repeatedRecords(b.N)
+	plan := Compile(ihdrLike)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := plan.Parse(r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
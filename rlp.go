@@ -0,0 +1,171 @@
+package construct
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RLP — Ethereum/devp2p's Recursive Length Prefix encoding. Self-delimiting
+// like LengthPrefixedString, so it slots into a Struct the same way: a
+// single byte <0x80 encodes itself; short strings/lists (<=55 bytes) get a
+// one-byte length prefix; longer ones get a length-of-length prefix instead.
+// ─────────────────────────────────────────────────────────────────────────────
+type RLP struct{}
+
+func (RLP) Parse(r io.Reader) (any, error) { return rlpParse(r) }
+func (RLP) Build(w io.Writer, v any) error {
+	b, err := rlpEncode(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func rlpParse(r io.Reader) (any, error) {
+	var first [1]byte
+	if _, err := io.ReadFull(r, first[:]); err != nil {
+		return nil, err
+	}
+	b := first[0]
+
+	switch {
+	case b < 0x80:
+		return []byte{b}, nil
+
+	case b < 0xb8:
+		buf := make([]byte, int(b-0x80))
+		_, err := io.ReadFull(r, buf)
+		return buf, err
+
+	case b < 0xc0:
+		n, err := rlpReadLength(r, int(b-0xb7))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, n)
+		_, err = io.ReadFull(r, buf)
+		return buf, err
+
+	case b < 0xf8:
+		payload := make([]byte, int(b-0xc0))
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, err
+		}
+		return rlpParseList(payload)
+
+	default:
+		n, err := rlpReadLength(r, int(b-0xf7))
+		if err != nil {
+			return nil, err
+		}
+		payload := make([]byte, n)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, err
+		}
+		return rlpParseList(payload)
+	}
+}
+
+func rlpReadLength(r io.Reader, lenOfLen int) (int, error) {
+	buf := make([]byte, lenOfLen)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, x := range buf {
+		n = n<<8 | uint64(x)
+	}
+	return int(n), nil
+}
+
+func rlpParseList(payload []byte) (any, error) {
+	r := bytes.NewReader(payload)
+	items := []any{}
+	for r.Len() > 0 {
+		v, err := rlpParse(r)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+// rlpEncode accepts []byte, string, any Go integer type, or []any (a list).
+func rlpEncode(v any) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return rlpEncodeString(x), nil
+	case string:
+		return rlpEncodeString([]byte(x)), nil
+	case []any:
+		items := make([][]byte, len(x))
+		for i, item := range x {
+			b, err := rlpEncode(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = b
+		}
+		return rlpEncodeList(items), nil
+	default:
+		if rv := reflect.ValueOf(v); rv.IsValid() {
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				if rv.Int() < 0 {
+					return nil, fmt.Errorf("construct: RLP: negative integers have no RLP representation: %v", v)
+				}
+			}
+		}
+		n, ok := toUint64(v)
+		if !ok {
+			return nil, fmt.Errorf("construct: RLP: unsupported value type %T", v)
+		}
+		return rlpEncodeString(rlpMinimalBigEndian(n)), nil
+	}
+}
+
+func rlpEncodeString(data []byte) []byte {
+	if len(data) == 1 && data[0] < 0x80 {
+		return data
+	}
+	if len(data) <= 55 {
+		return append([]byte{0x80 + byte(len(data))}, data...)
+	}
+	lenBytes := rlpMinimalBigEndian(uint64(len(data)))
+	out := append([]byte{0xb7 + byte(len(lenBytes))}, lenBytes...)
+	return append(out, data...)
+}
+
+func rlpEncodeList(items [][]byte) []byte {
+	var payload []byte
+	for _, item := range items {
+		payload = append(payload, item...)
+	}
+	if len(payload) <= 55 {
+		return append([]byte{0xc0 + byte(len(payload))}, payload...)
+	}
+	lenBytes := rlpMinimalBigEndian(uint64(len(payload)))
+	out := append([]byte{0xf7 + byte(len(lenBytes))}, lenBytes...)
+	return append(out, payload...)
+}
+
+// rlpMinimalBigEndian encodes n as big-endian bytes with no leading zero
+// byte; zero itself encodes as the empty slice, per the RLP spec.
+func rlpMinimalBigEndian(n uint64) []byte {
+	if n == 0 {
+		return nil
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], n)
+	i := 0
+	for i < len(buf) && buf[i] == 0 {
+		i++
+	}
+	return buf[i:]
+}
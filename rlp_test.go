@@ -0,0 +1,92 @@
+package construct
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRLP_String(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (RLP{}).Build(&buf, "dog"); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0x83, 'd', 'o', 'g'}) {
+		t.Errorf("unexpected encoding: %x", buf.Bytes())
+	}
+	v, err := (RLP{}).Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v.([]byte), []byte("dog")) {
+		t.Errorf("got %v, want \"dog\"", v)
+	}
+}
+
+func TestRLP_ZeroIsEmptyString(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (RLP{}).Build(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0x80}) {
+		t.Errorf("unexpected encoding: %x", buf.Bytes())
+	}
+}
+
+func TestRLP_List(t *testing.T) {
+	in := []any{"cat", "dog"}
+	var buf bytes.Buffer
+	if err := (RLP{}).Build(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{0xc8, 0x83, 'c', 'a', 't', 0x83, 'd', 'o', 'g'}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("unexpected encoding: %x", buf.Bytes())
+	}
+
+	v, err := (RLP{}).Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := v.([]any)
+	if len(items) != 2 || !bytes.Equal(items[0].([]byte), []byte("cat")) || !bytes.Equal(items[1].([]byte), []byte("dog")) {
+		t.Errorf("unexpected items: %v", items)
+	}
+}
+
+func TestRLP_NegativeIntegerRejected(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (RLP{}).Build(&buf, -1); err == nil {
+		t.Error("expected error building a negative integer, got nil")
+	}
+}
+
+func TestRLP_LargeUint64NotRejected(t *testing.T) {
+	var buf bytes.Buffer
+	in := uint64(1) << 63
+	if err := (RLP{}).Build(&buf, in); err != nil {
+		t.Fatalf("unexpected error building %d: %v", in, err)
+	}
+
+	v, err := (RLP{}).Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v.([]byte), rlpMinimalBigEndian(in)) {
+		t.Errorf("round trip mismatch for %d: got %x", in, v)
+	}
+}
+
+func TestRLP_LongString(t *testing.T) {
+	data := bytes.Repeat([]byte{'x'}, 56)
+	var buf bytes.Buffer
+	if err := (RLP{}).Build(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+	v, err := (RLP{}).Parse(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(v.([]byte), data) {
+		t.Errorf("round trip mismatch for long string")
+	}
+}
@@ -0,0 +1,333 @@
+package construct
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Marshal/Unmarshal — a reflection-based codec driven by `construct:"..."`
+// struct tags, so callers don't have to round-trip through []any by hand.
+// The tag compiles down to the same Field implementations used everywhere
+// else in the package, so a Struct and a tagged struct can read and write
+// the same stream one after the other. No tag embeds a Struct, and no Field
+// wraps a tagged Go struct.
+//
+// Supported tags:
+//   construct:"uint32be"                              primitive (see primitiveDefs)
+//   construct:"string,len=16"                         fixed-length string
+//   construct:"bytes,len=4"                           fixed-length []byte
+//   construct:"array,count=8,of=uint16le"              fixed-count array
+//   construct:"enum,type=uint8,map=Red:0;Green:1"      named constants
+//   construct:"const,hex=89504E47"                     magic bytes
+//   construct:"pad,len=3"                               zero-byte padding
+//
+// Fields without a `construct` tag are left untouched.
+// ─────────────────────────────────────────────────────────────────────────────
+
+// primitiveDefs maps a tag keyword to the existing Field that implements it
+// plus a zero value of the native Go type that Field expects/returns, so the
+// codec can reflect.Convert between that and whatever type the struct field
+// actually declares.
+var primitiveDefs = map[string]struct {
+	field Field
+	zero  any
+}{
+	"int8":      {Int8{}, int8(0)},
+	"uint8":     {Uint8{}, uint8(0)},
+	"int16be":   {Int16be{}, int16(0)},
+	"int16le":   {Int16le{}, int16(0)},
+	"uint16be":  {Uint16be{}, uint16(0)},
+	"uint16le":  {Uint16le{}, uint16(0)},
+	"int32be":   {Int32be{}, int32(0)},
+	"int32le":   {Int32le{}, int32(0)},
+	"uint32be":  {Uint32be{}, uint32(0)},
+	"uint32le":  {Uint32le{}, uint32(0)},
+	"int64be":   {Int64be{}, int64(0)},
+	"uint64be":  {Uint64be{}, uint64(0)},
+	"float32be": {Float32be{}, float32(0)},
+	"float64be": {Float64be{}, float64(0)},
+	"uvarint":   {Uvarint{}, uint64(0)},
+	"varint":    {Varint{}, int64(0)},
+}
+
+// compiledField is what a `construct` tag compiles to: the underlying Field
+// plus whatever extra bookkeeping the codec needs to move values in and out
+// of a reflect.Value (native-type zero value, enum reverse mapping, array
+// element compilation).
+type compiledField struct {
+	kind    string
+	field   Field
+	zero    any
+	of      *compiledField
+	count   int
+	mapping map[string]int64 // enum name -> value, for Marshal's reverse lookup
+}
+
+// compileTag parses a `construct` struct tag into a compiledField.
+func compileTag(tag string) (*compiledField, error) {
+	parts := strings.Split(tag, ",")
+	kind := parts[0]
+	params := map[string]string{}
+	for _, p := range parts[1:] {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("construct: malformed tag parameter %q", p)
+		}
+		params[kv[0]] = kv[1]
+	}
+
+	switch kind {
+	case "string":
+		n, err := strconv.Atoi(params["len"])
+		if err != nil {
+			return nil, fmt.Errorf("construct: string: %w", err)
+		}
+		return &compiledField{kind: "string", field: String{Length: n}}, nil
+
+	case "bytes":
+		n, err := strconv.Atoi(params["len"])
+		if err != nil {
+			return nil, fmt.Errorf("construct: bytes: %w", err)
+		}
+		return &compiledField{kind: "bytes", field: Bytes{Length: n}}, nil
+
+	case "array":
+		count, err := strconv.Atoi(params["count"])
+		if err != nil {
+			return nil, fmt.Errorf("construct: array: %w", err)
+		}
+		of, err := compileTag(params["of"])
+		if err != nil {
+			return nil, fmt.Errorf("construct: array: %w", err)
+		}
+		return &compiledField{kind: "array", count: count, of: of, field: Array{Count: count, Field: of.field}}, nil
+
+	case "enum":
+		def, ok := primitiveDefs[params["type"]]
+		if !ok {
+			return nil, fmt.Errorf("construct: enum: unknown underlying type %q", params["type"])
+		}
+		mapping, err := parseEnumMap(params["map"])
+		if err != nil {
+			return nil, fmt.Errorf("construct: enum: %w", err)
+		}
+		reverse := make(map[int64]string, len(mapping))
+		for name, val := range mapping {
+			reverse[val] = name
+		}
+		return &compiledField{
+			kind:    "enum",
+			field:   Enum{SubField: def.field, Mapping: reverse},
+			zero:    def.zero,
+			mapping: mapping,
+		}, nil
+
+	case "const":
+		value, err := hex.DecodeString(params["hex"])
+		if err != nil {
+			return nil, fmt.Errorf("construct: const: %w", err)
+		}
+		return &compiledField{kind: "const", field: Const{Value: value}}, nil
+
+	case "pad":
+		n, err := strconv.Atoi(params["len"])
+		if err != nil {
+			return nil, fmt.Errorf("construct: pad: %w", err)
+		}
+		return &compiledField{kind: "pad", field: Padding{Length: n}}, nil
+
+	default:
+		def, ok := primitiveDefs[kind]
+		if !ok {
+			return nil, fmt.Errorf("construct: unknown tag kind %q", kind)
+		}
+		return &compiledField{kind: "primitive", field: def.field, zero: def.zero}, nil
+	}
+}
+
+// parseEnumMap parses "Red:0;Green:1" into {"Red": 0, "Green": 1}.
+func parseEnumMap(s string) (map[string]int64, error) {
+	mapping := map[string]int64{}
+	for _, pair := range strings.Split(s, ";") {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("malformed map entry %q", pair)
+		}
+		val, err := strconv.ParseInt(kv[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed map value %q: %w", kv[1], err)
+		}
+		mapping[kv[0]] = val
+	}
+	return mapping, nil
+}
+
+// Marshal encodes v, which must be a struct or a pointer to one, to w
+// according to each field's `construct` tag.
+func Marshal(w io.Writer, v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errors.New("construct: Marshal requires a struct or pointer to struct")
+	}
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("construct")
+		if !ok {
+			continue
+		}
+		cf, err := compileTag(tag)
+		if err != nil {
+			return fmt.Errorf("construct: field %s: %w", sf.Name, err)
+		}
+		if err := marshalField(w, cf, rv.Field(i)); err != nil {
+			return fmt.Errorf("construct: field %s: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// Unmarshal decodes r into v, which must be a non-nil pointer to a struct,
+// according to each field's `construct` tag.
+func Unmarshal(r io.Reader, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("construct: Unmarshal requires a non-nil pointer to a struct")
+	}
+	rv = rv.Elem()
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("construct")
+		if !ok {
+			continue
+		}
+		cf, err := compileTag(tag)
+		if err != nil {
+			return fmt.Errorf("construct: field %s: %w", sf.Name, err)
+		}
+		if err := unmarshalField(r, cf, rv.Field(i)); err != nil {
+			return fmt.Errorf("construct: field %s: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+func marshalField(w io.Writer, cf *compiledField, fv reflect.Value) error {
+	switch cf.kind {
+	case "const", "pad":
+		return cf.field.Build(w, nil)
+	case "array":
+		if fv.Kind() != reflect.Slice && fv.Kind() != reflect.Array {
+			return fmt.Errorf("field type %s cannot hold an array tag", fv.Type())
+		}
+		if fv.Len() != cf.count {
+			return fmt.Errorf("expected length %d, got %d", cf.count, fv.Len())
+		}
+		for i := 0; i < cf.count; i++ {
+			if err := marshalField(w, cf.of, fv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "enum":
+		if fv.Kind() != reflect.String {
+			return fmt.Errorf("field type %s cannot hold an enum tag", fv.Type())
+		}
+		name := fv.String()
+		val, ok := cf.mapping[name]
+		if !ok {
+			return fmt.Errorf("unknown enum name %q", name)
+		}
+		return cf.field.(Enum).SubField.Build(w, reflect.ValueOf(val).Convert(reflect.TypeOf(cf.zero)).Interface())
+	case "string":
+		if fv.Kind() != reflect.String {
+			return fmt.Errorf("field type %s cannot hold a string tag", fv.Type())
+		}
+		return cf.field.Build(w, fv.String())
+	case "bytes":
+		if fv.Kind() != reflect.Slice || fv.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("field type %s cannot hold a bytes tag", fv.Type())
+		}
+		return cf.field.Build(w, fv.Bytes())
+	default:
+		zt := reflect.TypeOf(cf.zero)
+		if !fv.Type().ConvertibleTo(zt) {
+			return fmt.Errorf("field type %s cannot be converted to %s", fv.Type(), zt)
+		}
+		return cf.field.Build(w, fv.Convert(zt).Interface())
+	}
+}
+
+func unmarshalField(r io.Reader, cf *compiledField, fv reflect.Value) error {
+	switch cf.kind {
+	case "const", "pad":
+		_, err := cf.field.Parse(r)
+		return err
+	case "array":
+		if fv.Kind() != reflect.Slice {
+			return fmt.Errorf("field type %s cannot hold an array tag", fv.Type())
+		}
+		fv.Set(reflect.MakeSlice(fv.Type(), cf.count, cf.count))
+		for i := 0; i < cf.count; i++ {
+			if err := unmarshalField(r, cf.of, fv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "enum":
+		if fv.Kind() != reflect.String {
+			return fmt.Errorf("field type %s cannot hold an enum tag", fv.Type())
+		}
+		v, err := cf.field.Parse(r)
+		if err != nil {
+			return err
+		}
+		name, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("unmapped enum value %v", v)
+		}
+		fv.SetString(name)
+		return nil
+	case "string":
+		if fv.Kind() != reflect.String {
+			return fmt.Errorf("field type %s cannot hold a string tag", fv.Type())
+		}
+		v, err := cf.field.Parse(r)
+		if err != nil {
+			return err
+		}
+		fv.SetString(v.(string))
+		return nil
+	case "bytes":
+		if fv.Kind() != reflect.Slice || fv.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("field type %s cannot hold a bytes tag", fv.Type())
+		}
+		v, err := cf.field.Parse(r)
+		if err != nil {
+			return err
+		}
+		fv.SetBytes(v.([]byte))
+		return nil
+	default:
+		v, err := cf.field.Parse(r)
+		if err != nil {
+			return err
+		}
+		vt := reflect.TypeOf(v)
+		if !vt.ConvertibleTo(fv.Type()) {
+			return fmt.Errorf("parsed value of type %s cannot be converted to field type %s", vt, fv.Type())
+		}
+		fv.Set(reflect.ValueOf(v).Convert(fv.Type()))
+		return nil
+	}
+}
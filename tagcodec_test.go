@@ -0,0 +1,160 @@
+package construct
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarshalUnmarshal_Struct(t *testing.T) {
+	type packet struct {
+		Magic  []byte   `construct:"const,hex=89504E47"`
+		Width  uint32   `construct:"uint32be"`
+		Height uint32   `construct:"uint32be"`
+		Name   string   `construct:"string,len=5"`
+		Flags  []uint16 `construct:"array,count=2,of=uint16le"`
+	}
+
+	in := packet{Width: 1920, Height: 1080, Name: "Test", Flags: []uint16{1, 2}}
+
+	var buf bytes.Buffer
+	if err := Marshal(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+
+	var out packet
+	if err := Unmarshal(bytes.NewReader(buf.Bytes()), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Width != in.Width || out.Height != in.Height || out.Name != in.Name {
+		t.Errorf("unexpected round trip: %+v", out)
+	}
+	if len(out.Flags) != 2 || out.Flags[0] != 1 || out.Flags[1] != 2 {
+		t.Errorf("unexpected Flags: %v", out.Flags)
+	}
+}
+
+func TestMarshal_TypeMismatchReturnsError(t *testing.T) {
+	type bad struct {
+		X string `construct:"uint32be"`
+	}
+
+	var buf bytes.Buffer
+	if err := Marshal(&buf, bad{X: "nope"}); err == nil {
+		t.Error("expected error for string field tagged uint32be, got nil")
+	}
+}
+
+func TestMarshal_ArrayTagOnNonSliceReturnsError(t *testing.T) {
+	type bad struct {
+		X int `construct:"array,count=8,of=uint16le"`
+	}
+
+	var buf bytes.Buffer
+	if err := Marshal(&buf, bad{X: 5}); err == nil {
+		t.Error("expected error for int field tagged array, got nil")
+	}
+}
+
+func TestUnmarshal_ArrayTagOnNonSliceReturnsError(t *testing.T) {
+	type bad struct {
+		X int `construct:"array,count=8,of=uint16le"`
+	}
+
+	data := make([]byte, 16)
+	var out bad
+	if err := Unmarshal(bytes.NewReader(data), &out); err == nil {
+		t.Error("expected error unmarshaling into int field tagged array, got nil")
+	}
+}
+
+func TestMarshal_BytesTagOnNonSliceReturnsError(t *testing.T) {
+	type bad struct {
+		X int `construct:"bytes,len=4"`
+	}
+
+	var buf bytes.Buffer
+	if err := Marshal(&buf, bad{X: 5}); err == nil {
+		t.Error("expected error for int field tagged bytes, got nil")
+	}
+}
+
+func TestUnmarshal_BytesTagOnNonSliceReturnsError(t *testing.T) {
+	type bad struct {
+		X int `construct:"bytes,len=4"`
+	}
+
+	data := make([]byte, 4)
+	var out bad
+	if err := Unmarshal(bytes.NewReader(data), &out); err == nil {
+		t.Error("expected error unmarshaling into int field tagged bytes, got nil")
+	}
+}
+
+func TestMarshal_StringTagOnNonStringReturnsError(t *testing.T) {
+	type bad struct {
+		X int `construct:"string,len=4"`
+	}
+
+	var buf bytes.Buffer
+	if err := Marshal(&buf, bad{X: 5}); err == nil {
+		t.Error("expected error for int field tagged string, got nil")
+	}
+}
+
+func TestUnmarshal_StringTagOnNonStringReturnsError(t *testing.T) {
+	type bad struct {
+		X int `construct:"string,len=4"`
+	}
+
+	data := make([]byte, 4)
+	var out bad
+	if err := Unmarshal(bytes.NewReader(data), &out); err == nil {
+		t.Error("expected error unmarshaling into int field tagged string, got nil")
+	}
+}
+
+func TestMarshal_EnumTagOnNonStringReturnsError(t *testing.T) {
+	type bad struct {
+		X int `construct:"enum,type=uint8,map=Red:0;Green:1"`
+	}
+
+	var buf bytes.Buffer
+	if err := Marshal(&buf, bad{X: 5}); err == nil {
+		t.Error("expected error for int field tagged enum, got nil")
+	}
+}
+
+func TestUnmarshal_EnumTagOnNonStringReturnsError(t *testing.T) {
+	type bad struct {
+		X int `construct:"enum,type=uint8,map=Red:0;Green:1"`
+	}
+
+	data := []byte{0x00}
+	var out bad
+	if err := Unmarshal(bytes.NewReader(data), &out); err == nil {
+		t.Error("expected error unmarshaling into int field tagged enum, got nil")
+	}
+}
+
+func TestMarshalUnmarshal_Enum(t *testing.T) {
+	type colored struct {
+		Color string `construct:"enum,type=uint8,map=Red:0;Green:1;Blue:2"`
+	}
+
+	var buf bytes.Buffer
+	if err := Marshal(&buf, colored{Color: "Green"}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0x01}) {
+		t.Errorf("unexpected encoding: %x", buf.Bytes())
+	}
+
+	var out colored
+	if err := Unmarshal(bytes.NewReader(buf.Bytes()), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Color != "Green" {
+		t.Errorf("got %q, want Green", out.Color)
+	}
+}
@@ -0,0 +1,108 @@
+package construct
+
+import (
+	"errors"
+	"io"
+	"reflect"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Uvarint/Varint — LEB128-style variable-length integers (protobuf, DWARF,
+// WebAssembly, and friends all use this scheme: 7 data bits per byte, MSB set
+// on every byte but the last).
+// ─────────────────────────────────────────────────────────────────────────────
+
+// maxVarintBytes bounds the number of bytes a 64-bit varint can occupy
+// (ceil(64/7) = 10) and guards Parse against a malformed stream that never
+// clears its continuation bit.
+const maxVarintBytes = 10
+
+type Uvarint struct{}
+
+func (Uvarint) Parse(r io.Reader) (any, error) {
+	var buf [1]byte
+	var result uint64
+	var shift uint
+	for i := 0; i < maxVarintBytes; i++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return nil, err
+		}
+		b := buf[0]
+		result |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return result, nil
+		}
+		shift += 7
+	}
+	return nil, errors.New("Uvarint: varint overflows 64 bits")
+}
+
+func (Uvarint) Build(w io.Writer, v any) error {
+	u, ok := toUint64(v)
+	if !ok {
+		return errors.New("Uvarint: expected an integer value")
+	}
+	return writeUvarint(w, u)
+}
+
+type Varint struct{}
+
+func (Varint) Parse(r io.Reader) (any, error) {
+	v, err := (Uvarint{}).Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	u := v.(uint64)
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+func (Varint) Build(w io.Writer, v any) error {
+	i, ok := toInt64(v)
+	if !ok {
+		return errors.New("Varint: expected an integer value")
+	}
+	return writeUvarint(w, uint64(i<<1)^uint64(i>>63))
+}
+
+// writeUvarint encodes u as 7-bit groups LSB-first, setting the MSB on every
+// byte but the last.
+func writeUvarint(w io.Writer, u uint64) error {
+	var buf [maxVarintBytes]byte
+	n := 0
+	for u >= 0x80 {
+		buf[n] = byte(u) | 0x80
+		u >>= 7
+		n++
+	}
+	buf[n] = byte(u)
+	n++
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+// toUint64 coerces any Go integer type (signed or unsigned, any width) to a
+// uint64 so Build can accept whatever the caller has on hand.
+func toUint64(v any) (uint64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), true
+	default:
+		return 0, false
+	}
+}
+
+// toInt64 coerces any Go integer type to an int64 for Varint's zig-zag fold.
+func toInt64(v any) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
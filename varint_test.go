@@ -0,0 +1,43 @@
+package construct
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUvarint_Parse(t *testing.T) {
+	data := []byte{0xAC, 0x02} // 300
+	v, err := (Uvarint{}).Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != uint64(300) {
+		t.Errorf("got %v, want 300", v)
+	}
+}
+
+func TestUvarint_Build(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (Uvarint{}).Build(&buf, uint32(300)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), []byte{0xAC, 0x02}) {
+		t.Errorf("unexpected output: %x", buf.Bytes())
+	}
+}
+
+func TestVarint_RoundTrip(t *testing.T) {
+	for _, n := range []int64{0, 1, -1, 300, -300, 1 << 40, -(1 << 40)} {
+		var buf bytes.Buffer
+		if err := (Varint{}).Build(&buf, n); err != nil {
+			t.Fatal(err)
+		}
+		v, err := (Varint{}).Parse(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != n {
+			t.Errorf("round trip %d: got %v", n, v)
+		}
+	}
+}